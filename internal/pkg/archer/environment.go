@@ -0,0 +1,36 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package archer
+
+import "github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/manifest"
+
+// Environment is a named, deployable target within a Project (e.g. "test", "staging", "prod").
+type Environment struct {
+	Project            string            // name of the project this environment belongs to.
+	Name               string            // unique (per project) name of the environment.
+	Profile            string            // named AWS profile to deploy into, empty means the default credential chain.
+	Region             string            // AWS region to deploy into, empty means the session's configured region.
+	PublicLoadBalancer bool              // true means the environment's load balancer is internet-facing.
+	Tags               map[string]string // tags inherited from the owning Project, applied to every resource the environment creates.
+}
+
+// EnvironmentStore persists and retrieves Environments.
+type EnvironmentStore interface {
+	CreateEnvironment(env *Environment) error
+	ListEnvironments(project string) ([]*Environment, error)
+}
+
+// EnvironmentDeployer provisions the infrastructure for an Environment and deploys
+// applications into it.
+type EnvironmentDeployer interface {
+	// DeployEnvironment creates the CloudFormation stack backing env, tagging the
+	// stack with env.Tags. An existing stack is not updated: an error is returned.
+	DeployEnvironment(env *Environment) error
+	// WaitForEnvironmentCreation blocks until env's stack finishes creating.
+	WaitForEnvironmentCreation(env *Environment) error
+	// DeployApp creates (or updates) the ECS service and task definition for appName
+	// within env, translating m's sidecars into additional task-definition containers
+	// and tagging the ECS task with env.Tags.
+	DeployApp(env *Environment, appName string, m manifest.Manifest) error
+}
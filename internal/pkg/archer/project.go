@@ -0,0 +1,20 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archer holds the core domain types shared across the archer CLI and its
+// backing stores and deployers.
+package archer
+
+// Project is a namespace that groups a set of applications sharing a VPC, an ECS
+// cluster, and service discovery.
+type Project struct {
+	Name string            // unique name of the project.
+	Tags map[string]string // org-defined constraints (e.g. cost-allocation, compliance) applied to everything under the project.
+}
+
+// ProjectStore persists and retrieves Projects.
+type ProjectStore interface {
+	CreateProject(project *Project) error
+	GetProject(name string) (*Project, error)
+	ListProjects() ([]*Project, error)
+}
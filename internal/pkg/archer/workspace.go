@@ -0,0 +1,21 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package archer
+
+// WorkspaceSummary holds metadata about the project stored in the local workspace.
+type WorkspaceSummary struct {
+	ProjectName string // name of the project the current directory was initialized with.
+}
+
+// Workspace manages the local, on-disk representation of a project: its manifests
+// and any metadata needed to associate the directory with a project in the store.
+type Workspace interface {
+	// Create initializes the workspace for a new project.
+	Create(projectName string) error
+	// Summary returns the project the current directory belongs to, or an error if
+	// the directory hasn't been initialized yet.
+	Summary() (*WorkspaceSummary, error)
+	// WriteManifest persists manifestBytes for appName in the workspace.
+	WriteManifest(manifestBytes []byte, appName string) error
+}
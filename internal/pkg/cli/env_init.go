@@ -0,0 +1,215 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/cmd/archer/template"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/archer"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/deploy/cloudformation"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/store/ssm"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/term/prompt"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/term/spinner"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/workspace"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/spf13/cobra"
+)
+
+// InitEnvOpts holds the fields to bootstrap a new, named environment.
+type InitEnvOpts struct {
+	Project            string `survey:"project"` // namespace that this environment belongs to.
+	EnvName            string `survey:"name"`    // unique (per project) name of the environment, e.g. "test", "staging", "prod".
+	Profile            string // named AWS profile to use when deploying into this environment.
+	Region             string // AWS region to deploy this environment into.
+	PublicLoadBalancer bool   // true means the environment's load balancer should be internet-facing.
+
+	projStore   archer.ProjectStore
+	envStore    archer.EnvironmentStore
+	envDeployer archer.EnvironmentDeployer
+
+	ws               archer.Workspace
+	existingProjects []string
+
+	prog     progress
+	prompter prompter
+}
+
+// Ask prompts the user for the value of any required fields that are not already provided.
+func (opts *InitEnvOpts) Ask() error {
+	if opts.Project == "" {
+		if err := opts.projectQuestion(); err != nil {
+			return err
+		}
+	}
+
+	if opts.EnvName == "" {
+		name, err := opts.prompter.Get(
+			"What is your environment's name?",
+			"Unique identifier for this environment within the project, e.g. \"test\", \"staging\", \"prod\".",
+			validateEnvironmentName)
+
+		if err != nil {
+			return fmt.Errorf("failed to get environment name: %w", err)
+		}
+
+		opts.EnvName = name
+	}
+
+	return nil
+}
+
+func (opts *InitEnvOpts) projectQuestion() error {
+	if summary, err := opts.ws.Summary(); err == nil {
+		opts.Project = summary.ProjectName
+		return nil
+	}
+
+	existingProjects, _ := opts.projStore.ListProjects()
+	var projectNames []string
+	for _, p := range existingProjects {
+		projectNames = append(projectNames, p.Name)
+	}
+	opts.existingProjects = projectNames
+
+	if len(opts.existingProjects) > 0 {
+		projectName, err := opts.prompter.SelectOne(
+			"Which project should we use?",
+			"Choose the project to create a new environment in.",
+			opts.existingProjects)
+
+		if err != nil {
+			return fmt.Errorf("failed to get project selection: %w", err)
+		}
+
+		opts.Project = projectName
+
+		return nil
+	}
+
+	projectName, err := opts.prompter.Get(
+		"What is your project's name?",
+		"Applications under the same project share the same VPC and ECS Cluster and are discoverable via service discovery.",
+		validateProjectName)
+
+	if err != nil {
+		return fmt.Errorf("failed to get project name: %w", err)
+	}
+
+	opts.Project = projectName
+
+	return nil
+}
+
+// Validate returns an error if a command line flag provided value is invalid
+func (opts *InitEnvOpts) Validate() error {
+	if err := validateProjectName(opts.Project); err != nil && err != errValueEmpty {
+		return fmt.Errorf("project name invalid: %v", err)
+	}
+
+	if err := validateEnvironmentName(opts.EnvName); err != nil && err != errValueEmpty {
+		return fmt.Errorf("environment name invalid: %v", err)
+	}
+
+	return nil
+}
+
+// Execute deploys a new, named environment and persists its configuration.
+func (opts *InitEnvOpts) Execute() error {
+	proj, err := opts.projStore.GetProject(opts.Project)
+	if err != nil {
+		return fmt.Errorf("failed to get project %s: %w", opts.Project, err)
+	}
+
+	env := &archer.Environment{
+		Project:            opts.Project,
+		Name:               opts.EnvName,
+		Profile:            opts.Profile,
+		Region:             opts.Region,
+		PublicLoadBalancer: opts.PublicLoadBalancer,
+		Tags:               proj.Tags,
+	}
+
+	opts.prog.Start("Preparing deployment...")
+	if err := opts.envDeployer.DeployEnvironment(env); err != nil {
+		var existsErr *cloudformation.ErrStackAlreadyExists
+		if errors.As(err, &existsErr) {
+			opts.prog.Stop("Done!")
+			fmt.Printf("The environment %s already exists under project %s.\n", env.Name, opts.Project)
+			return nil
+		}
+		opts.prog.Stop("Error!")
+		return err
+	}
+	opts.prog.Stop("Done!")
+
+	opts.prog.Start("Deploying env...")
+	if err := opts.envDeployer.WaitForEnvironmentCreation(env); err != nil {
+		opts.prog.Stop("Error!")
+		return err
+	}
+	if err := opts.envStore.CreateEnvironment(env); err != nil {
+		opts.prog.Stop("Error!")
+		return err
+	}
+	opts.prog.Stop("Done!")
+	return nil
+}
+
+// BuildEnvInitCmd builds the command for bootstrapping a new, named environment.
+func BuildEnvInitCmd() *cobra.Command {
+	opts := InitEnvOpts{
+		prompter: prompt.New(),
+		prog:     spinner.New(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a new environment",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := workspace.New()
+			if err != nil {
+				return err
+			}
+			opts.ws = ws
+
+			ssm, err := ssm.NewStore()
+			if err != nil {
+				return err
+			}
+			opts.projStore = ssm
+			opts.envStore = ssm
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				Profile:           opts.Profile,
+				Config:            aws.Config{Region: aws.String(opts.Region)},
+				SharedConfigState: session.SharedConfigEnable,
+			})
+			if err != nil {
+				return err
+			}
+			opts.envDeployer = cloudformation.New(sess)
+
+			return opts.Ask()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", "Name of the project.")
+	cmd.Flags().StringVarP(&opts.EnvName, "name", "n", "", "Name of the environment.")
+	cmd.Flags().StringVar(&opts.Profile, "profile", "", "Name of the AWS profile to deploy this environment with.")
+	cmd.Flags().StringVar(&opts.Region, "region", "", "AWS region to deploy this environment into.")
+	cmd.Flags().BoolVar(&opts.PublicLoadBalancer, "public", true, "Make the environment's load balancer internet-facing.")
+	cmd.SetUsageTemplate(template.Usage)
+	cmd.Annotations = map[string]string{
+		"group": "Getting Started ✨",
+	}
+	return cmd
+}
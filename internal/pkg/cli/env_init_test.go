@@ -0,0 +1,204 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/archer"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/deploy/cloudformation"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/manifest"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/store"
+)
+
+type fakeWorkspace struct {
+	summary archer.WorkspaceSummary
+	err     error
+}
+
+func (ws *fakeWorkspace) Create(projectName string) error { return nil }
+
+func (ws *fakeWorkspace) Summary() (*archer.WorkspaceSummary, error) {
+	if ws.err != nil {
+		return nil, ws.err
+	}
+	return &ws.summary, nil
+}
+
+func (ws *fakeWorkspace) WriteManifest(manifestBytes []byte, appName string) error { return nil }
+
+type fakeProjectStore struct {
+	projects []*archer.Project
+}
+
+func (s *fakeProjectStore) CreateProject(project *archer.Project) error { return nil }
+
+func (s *fakeProjectStore) GetProject(name string) (*archer.Project, error) {
+	for _, p := range s.projects {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, &store.ErrNoSuchProject{ProjectName: name}
+}
+
+func (s *fakeProjectStore) ListProjects() ([]*archer.Project, error) {
+	return s.projects, nil
+}
+
+type fakeEnvironmentStore struct {
+	created []*archer.Environment
+}
+
+func (s *fakeEnvironmentStore) CreateEnvironment(env *archer.Environment) error {
+	s.created = append(s.created, env)
+	return nil
+}
+
+func (s *fakeEnvironmentStore) ListEnvironments(project string) ([]*archer.Environment, error) {
+	return nil, nil
+}
+
+type fakeEnvironmentDeployer struct {
+	deployErr error
+	deployed  []*archer.Environment
+}
+
+func (d *fakeEnvironmentDeployer) DeployEnvironment(env *archer.Environment) error {
+	if d.deployErr != nil {
+		return d.deployErr
+	}
+	d.deployed = append(d.deployed, env)
+	return nil
+}
+
+func (d *fakeEnvironmentDeployer) WaitForEnvironmentCreation(env *archer.Environment) error {
+	return nil
+}
+
+func (d *fakeEnvironmentDeployer) DeployApp(env *archer.Environment, appName string, m manifest.Manifest) error {
+	return nil
+}
+
+type fakePrompter struct {
+	got      string
+	gotErr   error
+	selected string
+	selErr   error
+}
+
+func (p *fakePrompter) Get(prompt, help string, validator func(string) error) (string, error) {
+	return p.got, p.gotErr
+}
+
+func (p *fakePrompter) SelectOne(prompt, help string, options []string) (string, error) {
+	return p.selected, p.selErr
+}
+
+func (p *fakePrompter) Confirm(prompt, help string) (bool, error) {
+	return true, nil
+}
+
+type fakeProgress struct{}
+
+func (p *fakeProgress) Start(label string) {}
+func (p *fakeProgress) Stop(label string)  {}
+
+func TestInitEnvOpts_Validate(t *testing.T) {
+	t.Run("rejects an invalid environment name", func(t *testing.T) {
+		opts := InitEnvOpts{Project: "my-proj", EnvName: "Not Valid"}
+		if err := opts.Validate(); err == nil {
+			t.Fatal("expected an error for an invalid environment name")
+		}
+	})
+
+	t.Run("accepts well-formed names", func(t *testing.T) {
+		opts := InitEnvOpts{Project: "my-proj", EnvName: "test"}
+		if err := opts.Validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestInitEnvOpts_projectQuestion(t *testing.T) {
+	t.Run("infers the project from the workspace summary", func(t *testing.T) {
+		opts := InitEnvOpts{
+			ws: &fakeWorkspace{summary: archer.WorkspaceSummary{ProjectName: "my-proj"}},
+		}
+		if err := opts.projectQuestion(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Project != "my-proj" {
+			t.Fatalf("got project %q, want my-proj", opts.Project)
+		}
+	})
+
+	t.Run("offers existing projects to choose from outside a workspace", func(t *testing.T) {
+		opts := InitEnvOpts{
+			ws:        &fakeWorkspace{err: errors.New("not a workspace")},
+			projStore: &fakeProjectStore{projects: []*archer.Project{{Name: "my-proj"}, {Name: "other-proj"}}},
+			prompter:  &fakePrompter{selected: "other-proj"},
+		}
+		if err := opts.projectQuestion(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Project != "other-proj" {
+			t.Fatalf("got project %q, want other-proj", opts.Project)
+		}
+	})
+
+	t.Run("prompts for a brand new project name when none exist", func(t *testing.T) {
+		opts := InitEnvOpts{
+			ws:        &fakeWorkspace{err: errors.New("not a workspace")},
+			projStore: &fakeProjectStore{},
+			prompter:  &fakePrompter{got: "my-proj"},
+		}
+		if err := opts.projectQuestion(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Project != "my-proj" {
+			t.Fatalf("got project %q, want my-proj", opts.Project)
+		}
+	})
+}
+
+func TestInitEnvOpts_Execute(t *testing.T) {
+	t.Run("persists the environment once it deploys", func(t *testing.T) {
+		envStore := &fakeEnvironmentStore{}
+		opts := InitEnvOpts{
+			Project:     "my-proj",
+			EnvName:     "test",
+			projStore:   &fakeProjectStore{projects: []*archer.Project{{Name: "my-proj", Tags: map[string]string{"team": "frontend"}}}},
+			envStore:    envStore,
+			envDeployer: &fakeEnvironmentDeployer{},
+			prog:        &fakeProgress{},
+		}
+		if err := opts.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(envStore.created) != 1 {
+			t.Fatalf("got %d environments created, want 1", len(envStore.created))
+		}
+		if got := envStore.created[0].Tags["team"]; got != "frontend" {
+			t.Fatalf("got tag %q, want frontend", got)
+		}
+	})
+
+	t.Run("treats an already-deployed environment as success", func(t *testing.T) {
+		opts := InitEnvOpts{
+			Project:   "my-proj",
+			EnvName:   "test",
+			projStore: &fakeProjectStore{projects: []*archer.Project{{Name: "my-proj"}}},
+			envStore:  &fakeEnvironmentStore{},
+			envDeployer: &fakeEnvironmentDeployer{
+				deployErr: &cloudformation.ErrStackAlreadyExists{StackName: "archer-my-proj-test"},
+			},
+			prog: &fakeProgress{},
+		}
+		if err := opts.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -7,6 +7,9 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/aws/PRIVATE-amazon-ecs-archer/cmd/archer/template"
 	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/archer"
@@ -23,13 +26,26 @@ import (
 
 const defaultEnvironmentName = "test"
 
+// nonInteractiveEnvVar, when set to a truthy value, has the same effect as passing --yes.
+const nonInteractiveEnvVar = "ARCHER_NON_INTERACTIVE"
+
+// errRequiredFlagMissing is returned by Ask in non-interactive mode when a required field wasn't provided via flags.
+var errRequiredFlagMissing = errors.New("required flag missing in non-interactive mode")
+
 // InitAppOpts holds the fields to bootstrap a new application.
 type InitAppOpts struct {
-	Project          string `survey:"project"` // namespace that this application belongs to.
-	AppName          string `survey:"name"`    // unique identifier for the application.
-	AppType          string `survey:"Type"`    // type of application you're trying to build (LoadBalanced, Backend, etc.)
-	ShouldDeploy     bool   // true means we should create a test environment and deploy the application in it. Exclusive with ShouldSkipDeploy.
-	ShouldSkipDeploy bool   // true means we should not create a test environment and not deploy the application in it. Exclusive with ShouldDeploy.
+	Project            string   `survey:"project"` // namespace that this application belongs to.
+	AppName            string   `survey:"name"`    // unique identifier for the application.
+	AppType            string   `survey:"Type"`    // type of application you're trying to build (LoadBalanced, Backend, etc.)
+	TemplateRepo       string   // Git URL of a repo containing a custom application template. Exclusive with TemplateDir.
+	TemplateDir        string   // local path to a custom application template. Exclusive with TemplateRepo.
+	EnvName            string   // name of the environment to deploy the application into, e.g. "test", "staging", "prod".
+	ShouldDeploy       bool     // true means we should create a test environment and deploy the application in it. Exclusive with ShouldSkipDeploy.
+	ShouldSkipDeploy   bool     // true means we should not create a test environment and not deploy the application in it. Exclusive with ShouldDeploy.
+	NonInteractive     bool     // true means Ask must not prompt and should fail fast if a required field is missing.
+	ProjectTags        []string // repeatable "key=value" constraints applied to the project and everything deployed under it.
+	SkipPostInitHook   bool     // true means never run the template's post-init hook.
+	AllowTemplateHooks bool     // true means post-init hooks from --template-repo/--template-dir are allowed to run.
 
 	projStore   archer.ProjectStore
 	envStore    archer.EnvironmentStore
@@ -43,7 +59,13 @@ type InitAppOpts struct {
 }
 
 // Ask prompts the user for the value of any required fields that are not already provided.
+// In non-interactive mode it never prompts: missing required fields are a hard error and
+// missing optional fields fall back to sensible defaults.
 func (opts *InitAppOpts) Ask() error {
+	if opts.NonInteractive {
+		return opts.askNonInteractive()
+	}
+
 	if opts.Project == "" {
 		if err := opts.projectQuestion(); err != nil {
 			return err
@@ -62,11 +84,16 @@ func (opts *InitAppOpts) Ask() error {
 
 		opts.AppName = name
 	}
-	if opts.AppType == "" {
+	if opts.AppType == "" && opts.TemplateRepo == "" && opts.TemplateDir == "" {
+		templates, err := manifest.AvailableTemplates()
+		if err != nil {
+			return fmt.Errorf("failed to list available templates: %w", err)
+		}
+
 		t, err := opts.prompter.SelectOne(
 			"Which template would you like to use?",
-			"Pre-defined infrastructure templates.",
-			[]string{manifest.LoadBalancedWebApplication})
+			"Pre-defined infrastructure templates, plus any custom templates cached in ~/.archer/templates.",
+			templates)
 
 		if err != nil {
 			return fmt.Errorf("failed to get template selection: %w", err)
@@ -78,6 +105,28 @@ func (opts *InitAppOpts) Ask() error {
 	return nil
 }
 
+// askNonInteractive fails fast if a required field is missing instead of prompting,
+// and fills in optional fields with the defaults Ask would otherwise offer interactively.
+func (opts *InitAppOpts) askNonInteractive() error {
+	if opts.Project == "" {
+		return fmt.Errorf("%w: --project is required with --non-interactive", errRequiredFlagMissing)
+	}
+
+	if opts.AppName == "" {
+		return fmt.Errorf("%w: --app is required with --non-interactive", errRequiredFlagMissing)
+	}
+
+	if opts.AppType == "" && opts.TemplateRepo == "" && opts.TemplateDir == "" {
+		opts.AppType = manifest.LoadBalancedWebApplication
+	}
+
+	if !opts.ShouldDeploy {
+		opts.ShouldSkipDeploy = true
+	}
+
+	return nil
+}
+
 func (opts *InitAppOpts) projectQuestion() error {
 	if len(opts.existingProjects) > 0 {
 		projectName, err := opts.prompter.SelectOne(
@@ -110,6 +159,10 @@ func (opts *InitAppOpts) projectQuestion() error {
 
 // Validate returns an error if a command line flag provided value is invalid
 func (opts *InitAppOpts) Validate() error {
+	if opts.TemplateRepo != "" && opts.TemplateDir != "" {
+		return errors.New("--template-repo and --template-dir cannot both be set")
+	}
+
 	if err := validateProjectName(opts.Project); err != nil && err != errValueEmpty {
 		return fmt.Errorf("project name invalid: %v", err)
 	}
@@ -118,6 +171,10 @@ func (opts *InitAppOpts) Validate() error {
 		return fmt.Errorf("application name invalid: %v", err)
 	}
 
+	if _, err := parseTags(opts.ProjectTags); err != nil {
+		return fmt.Errorf("--project-tag invalid: %w", err)
+	}
+
 	return nil
 }
 
@@ -156,27 +213,89 @@ func (opts *InitAppOpts) Execute() error {
 		return err
 	}
 
-	if err := opts.createApp(); err != nil {
+	m, err := opts.createApp()
+	if err != nil {
 		return err
 	}
 
-	return opts.deployEnv()
+	return opts.deployEnv(m)
 }
-func (opts *InitAppOpts) createApp() error {
-	manifest, err := manifest.Create(opts.AppName, opts.AppType)
+
+// createApp resolves the manifest for opts.AppType (or a custom --template-repo/
+// --template-dir), writes it to the workspace and runs its post-init hook, returning
+// the resolved Manifest so later steps can deploy its containers.
+func (opts *InitAppOpts) createApp() (manifest.Manifest, error) {
+	var m manifest.Manifest
+	var err error
+	switch {
+	case opts.TemplateRepo != "":
+		m, err = manifest.CreateFromGit(opts.AppName, opts.TemplateRepo)
+	case opts.TemplateDir != "":
+		m, err = manifest.CreateFromDir(opts.AppName, opts.TemplateDir)
+	default:
+		m, err = manifest.Create(opts.AppName, opts.AppType)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to generate a manifest %w", err)
+		return nil, fmt.Errorf("failed to generate a manifest %w", err)
 	}
-	manifestBytes, err := manifest.Marshal()
+	manifestBytes, err := m.Marshal()
 	if err != nil {
-		return fmt.Errorf("failed to marshal the manifest file %w", err)
+		return nil, fmt.Errorf("failed to marshal the manifest file %w", err)
+	}
+	if err := opts.ws.WriteManifest(manifestBytes, opts.AppName); err != nil {
+		return nil, err
 	}
-	return opts.ws.WriteManifest(manifestBytes, opts.AppName)
+
+	if err := opts.runPostInitHook(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// runPostInitHook runs the template's declared post-init command (e.g. "npm install",
+// "terraform init"), if any, from the current directory. The hook is skipped entirely
+// if the user passed --skip-post-init-hook. Untrusted manifests - anything sourced
+// from --template-repo/--template-dir, including once it's cached under
+// ~/.archer/templates and reselected by plain name - additionally require
+// --allow-template-hooks, since they can declare an arbitrary shell command. Whether
+// a manifest is untrusted travels with the resolved Manifest itself (m.Trusted()),
+// not with which flag happened to be passed on this invocation.
+func (opts *InitAppOpts) runPostInitHook(m manifest.Manifest) error {
+	hook := m.PostInitHook()
+	if hook == "" {
+		return nil
+	}
+
+	if opts.SkipPostInitHook {
+		return nil
+	}
+
+	if !m.Trusted() && !opts.AllowTemplateHooks {
+		fmt.Printf("Skipping post-init hook %q from an untrusted template; pass --allow-template-hooks to run it.\n", hook)
+		return nil
+	}
+
+	opts.prog.Start(fmt.Sprintf("Running post-init hook %q...", hook))
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		opts.prog.Stop("Error!")
+		return fmt.Errorf("failed to run post-init hook %q: %w", hook, err)
+	}
+	opts.prog.Stop("Done!")
+	return nil
 }
 
 func (opts *InitAppOpts) createProject() error {
-	err := opts.projStore.CreateProject(&archer.Project{
+	tags, err := parseTags(opts.ProjectTags)
+	if err != nil {
+		return fmt.Errorf("--project-tag invalid: %w", err)
+	}
+
+	err = opts.projStore.CreateProject(&archer.Project{
 		Name: opts.Project,
+		Tags: tags,
 	})
 	// If the project already exists, that's ok - otherwise
 	// return the error.
@@ -187,38 +306,56 @@ func (opts *InitAppOpts) createProject() error {
 	return nil
 }
 
-// deployEnv prompts the user to deploy a test environment if the project doesn't already have one.
-func (opts *InitAppOpts) deployEnv() error {
+// deployEnv sets up the --env environment (default "test") if the project doesn't
+// already have one by that name, asking first unless in non-interactive mode, then
+// deploys m - translating its sidecars into additional ECS task-definition
+// containers - as the app's first revision in it. If the project already has that
+// environment, or its stack already exists, nothing is deployed.
+func (opts *InitAppOpts) deployEnv(m manifest.Manifest) error {
 
 	if opts.ShouldSkipDeploy {
 		return nil
 	}
 
+	envName := opts.EnvName
+	if envName == "" {
+		envName = defaultEnvironmentName
+	}
+
 	existingEnvs, _ := opts.envStore.ListEnvironments(opts.Project)
-	if len(existingEnvs) > 0 {
-		return nil
+	for _, e := range existingEnvs {
+		if e.Name == envName {
+			return nil
+		}
 	}
 
-	deployEnv := false
+	deployEnv := opts.NonInteractive
 
-	deployEnv, err := opts.prompter.Confirm(
-		"Would you like to set up a test environment?",
-		"You can deploy your app into your test environment.")
+	if !opts.NonInteractive {
+		var err error
+		deployEnv, err = opts.prompter.Confirm(
+			fmt.Sprintf("Would you like to set up a %s environment?", envName),
+			"You can deploy your app into this environment.")
 
-	if err != nil {
-		// TODO: handle error?
+		if err != nil {
+			// TODO: handle error?
+		}
 	}
 
 	if !deployEnv {
 		return nil
 	}
 
-	// TODO: prompt the user for an environment name with default value "test"
-	// https://github.com/aws/PRIVATE-amazon-ecs-archer/issues/56
+	proj, err := opts.projStore.GetProject(opts.Project)
+	if err != nil {
+		return fmt.Errorf("failed to get project %s: %w", opts.Project, err)
+	}
+
 	env := &archer.Environment{
 		Project:            opts.Project,
-		Name:               defaultEnvironmentName,
+		Name:               envName,
 		PublicLoadBalancer: true, // TODO: configure this value based on user input or Application type needs?
+		Tags:               proj.Tags,
 	}
 
 	opts.prog.Start("Preparing deployment...")
@@ -244,9 +381,44 @@ func (opts *InitAppOpts) deployEnv() error {
 		return err
 	}
 	opts.prog.Stop("Done!")
+
+	opts.prog.Start(fmt.Sprintf("Deploying %s...", opts.AppName))
+	if err := opts.envDeployer.DeployApp(env, opts.AppName, m); err != nil {
+		opts.prog.Stop("Error!")
+		return err
+	}
+	opts.prog.Stop("Done!")
 	return nil
 }
 
+// parseTags parses a list of "key=value" pairs, as provided via repeated --project-tag flags,
+// into a map. An empty list returns a nil map.
+func parseTags(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%q must be in the form key=value", pair)
+		}
+		tags[parts[0]] = parts[1]
+	}
+	return tags, nil
+}
+
+// isEnvVarTruthy returns true if v looks like it was meant to enable a boolean flag.
+func isEnvVarTruthy(v string) bool {
+	switch v {
+	case "1", "true", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
 // BuildInitCmd builds the command for bootstrapping an application.
 func BuildInitCmd() *cobra.Command {
 	opts := InitAppOpts{
@@ -258,6 +430,21 @@ func BuildInitCmd() *cobra.Command {
 		Use:   "init",
 		Short: "Create a new ECS application",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			if !opts.NonInteractive {
+				opts.NonInteractive = isEnvVarTruthy(os.Getenv(nonInteractiveEnvVar))
+			}
+
+			// Fail fast on missing required flags before touching the workspace or AWS.
+			if opts.NonInteractive {
+				if err := opts.askNonInteractive(); err != nil {
+					return err
+				}
+			}
+
 			ws, err := workspace.New()
 			if err != nil {
 				return err
@@ -279,7 +466,9 @@ func BuildInitCmd() *cobra.Command {
 			}
 			opts.envDeployer = cloudformation.New(sess)
 
-			opts.Prepare()
+			if !opts.NonInteractive {
+				opts.Prepare()
+			}
 			return opts.Ask()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -289,8 +478,16 @@ func BuildInitCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", "Name of the project.")
 	cmd.Flags().StringVarP(&opts.AppName, "app", "a", "", "Name of the application.")
 	cmd.Flags().StringVarP(&opts.AppType, "app-type", "t", "", "Type of application to create.")
+	cmd.Flags().StringVar(&opts.TemplateRepo, "template-repo", "", "Git URL of a custom application template (exclusive with --template-dir).")
+	cmd.Flags().StringVar(&opts.TemplateDir, "template-dir", "", "Local path to a custom application template (exclusive with --template-repo).")
+	cmd.Flags().StringVarP(&opts.EnvName, "env", "e", "", fmt.Sprintf("Name of the environment to deploy into (default %q).", defaultEnvironmentName))
 	cmd.Flags().BoolVar(&opts.ShouldDeploy, "deploy", false, "Deploy your application to a \"test\" environment (exclusive with --skip-deploy).")
 	cmd.Flags().BoolVar(&opts.ShouldSkipDeploy, "skip-deploy", false, "Skip deploying your application (exclusive with --deploy).")
+	cmd.Flags().BoolVarP(&opts.NonInteractive, "yes", "y", false, fmt.Sprintf("Never prompt; fail if a required flag is missing (also honored via %s=1).", nonInteractiveEnvVar))
+	cmd.Flags().BoolVar(&opts.NonInteractive, "non-interactive", false, "Same as --yes.")
+	cmd.Flags().StringArrayVar(&opts.ProjectTags, "project-tag", nil, "Key=value constraint applied to the project and everything deployed under it (repeatable).")
+	cmd.Flags().BoolVar(&opts.SkipPostInitHook, "skip-post-init-hook", false, "Don't run the template's post-init hook.")
+	cmd.Flags().BoolVar(&opts.AllowTemplateHooks, "allow-template-hooks", false, "Allow running the post-init hook declared by a --template-repo/--template-dir template (off by default; these run arbitrary shell commands).")
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
 		"group": "Getting Started ✨",
@@ -0,0 +1,226 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/manifest"
+)
+
+func TestIsEnvVarTruthy(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"1 is truthy":      {in: "1", want: true},
+		"true is truthy":   {in: "true", want: true},
+		"yes is truthy":    {in: "yes", want: true},
+		"empty is falsy":   {in: "", want: false},
+		"0 is falsy":       {in: "0", want: false},
+		"garbage is falsy": {in: "definitely not", want: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isEnvVarTruthy(tc.in); got != tc.want {
+				t.Fatalf("isEnvVarTruthy(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInitAppOpts_askNonInteractive(t *testing.T) {
+	t.Run("missing project is an error", func(t *testing.T) {
+		opts := InitAppOpts{AppName: "api"}
+		if err := opts.askNonInteractive(); !errors.Is(err, errRequiredFlagMissing) {
+			t.Fatalf("got %v, want errRequiredFlagMissing", err)
+		}
+	})
+
+	t.Run("missing app name is an error", func(t *testing.T) {
+		opts := InitAppOpts{Project: "my-proj"}
+		if err := opts.askNonInteractive(); !errors.Is(err, errRequiredFlagMissing) {
+			t.Fatalf("got %v, want errRequiredFlagMissing", err)
+		}
+	})
+
+	t.Run("defaults app type and skips deploy when required fields are set", func(t *testing.T) {
+		opts := InitAppOpts{Project: "my-proj", AppName: "api"}
+		if err := opts.askNonInteractive(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.AppType != manifest.LoadBalancedWebApplication {
+			t.Fatalf("got AppType %q, want %q", opts.AppType, manifest.LoadBalancedWebApplication)
+		}
+		if !opts.ShouldSkipDeploy {
+			t.Fatalf("expected ShouldSkipDeploy to default to true")
+		}
+	})
+
+	t.Run("does not force skip-deploy when --deploy was passed", func(t *testing.T) {
+		opts := InitAppOpts{Project: "my-proj", AppName: "api", ShouldDeploy: true}
+		if err := opts.askNonInteractive(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.ShouldSkipDeploy {
+			t.Fatalf("expected ShouldSkipDeploy to stay false when ShouldDeploy is set")
+		}
+	})
+
+	t.Run("leaves an explicit template alone", func(t *testing.T) {
+		opts := InitAppOpts{Project: "my-proj", AppName: "api", TemplateRepo: "https://example.com/tmpl.git"}
+		if err := opts.askNonInteractive(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.AppType != "" {
+			t.Fatalf("got AppType %q, want empty since a custom template was requested", opts.AppType)
+		}
+	})
+}
+
+func TestInitAppOpts_Validate(t *testing.T) {
+	t.Run("rejects --template-repo and --template-dir together", func(t *testing.T) {
+		opts := InitAppOpts{
+			Project:      "my-proj",
+			AppName:      "api",
+			TemplateRepo: "https://example.com/tmpl.git",
+			TemplateDir:  "/tmp/tmpl",
+		}
+		if err := opts.Validate(); err == nil {
+			t.Fatal("expected an error when both --template-repo and --template-dir are set")
+		}
+	})
+
+	t.Run("rejects a malformed --project-tag", func(t *testing.T) {
+		opts := InitAppOpts{
+			Project:     "my-proj",
+			AppName:     "api",
+			ProjectTags: []string{"not-a-key-value-pair"},
+		}
+		if err := opts.Validate(); err == nil {
+			t.Fatal("expected an error for a malformed --project-tag")
+		}
+	})
+
+	t.Run("accepts a single template source and well-formed tags", func(t *testing.T) {
+		opts := InitAppOpts{
+			Project:      "my-proj",
+			AppName:      "api",
+			TemplateRepo: "https://example.com/tmpl.git",
+			ProjectTags:  []string{"team=frontend"},
+		}
+		if err := opts.Validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+type fakeManifest struct {
+	postInitHook string
+	sidecars     []manifest.Sidecar
+	trusted      bool
+}
+
+func (m *fakeManifest) Marshal() ([]byte, error)     { return nil, nil }
+func (m *fakeManifest) PostInitHook() string         { return m.postInitHook }
+func (m *fakeManifest) Sidecars() []manifest.Sidecar { return m.sidecars }
+func (m *fakeManifest) Trusted() bool                { return m.trusted }
+
+func TestInitAppOpts_runPostInitHook(t *testing.T) {
+	t.Run("does nothing when the manifest declares no hook", func(t *testing.T) {
+		opts := InitAppOpts{prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{trusted: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("skips the hook when --skip-post-init-hook is set", func(t *testing.T) {
+		opts := InitAppOpts{SkipPostInitHook: true, prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{postInitHook: "exit 1", trusted: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("skips an untrusted hook without --allow-template-hooks", func(t *testing.T) {
+		opts := InitAppOpts{prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{postInitHook: "exit 1", trusted: false}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("runs a trusted hook", func(t *testing.T) {
+		opts := InitAppOpts{prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{postInitHook: "true", trusted: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("runs an untrusted hook once allowed", func(t *testing.T) {
+		opts := InitAppOpts{AllowTemplateHooks: true, prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{postInitHook: "true", trusted: false}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("surfaces the command's error", func(t *testing.T) {
+		opts := InitAppOpts{prog: &fakeProgress{}}
+		if err := opts.runPostInitHook(&fakeManifest{postInitHook: "exit 1", trusted: true}); err == nil {
+			t.Fatal("expected an error from a failing hook")
+		}
+	})
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := map[string]struct {
+		in      []string
+		want    map[string]string
+		wantErr bool
+	}{
+		"empty input returns nil map": {
+			in:   nil,
+			want: nil,
+		},
+		"parses key=value pairs": {
+			in:   []string{"team=frontend", "env=prod"},
+			want: map[string]string{"team": "frontend", "env": "prod"},
+		},
+		"value may itself contain an equals sign": {
+			in:   []string{"query=a=b"},
+			want: map[string]string{"query": "a=b"},
+		},
+		"missing equals sign is an error": {
+			in:      []string{"team"},
+			wantErr: true,
+		},
+		"empty key is an error": {
+			in:      []string{"=frontend"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseTags(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for k, v := range tc.want {
+				if got[k] != v {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+// prompter asks the user for input on the terminal.
+type prompter interface {
+	// Get prompts for free-form text, re-asking while validator rejects the answer.
+	Get(prompt, help string, validator func(string) error) (string, error)
+	// SelectOne prompts the user to choose one of options.
+	SelectOne(prompt, help string, options []string) (string, error)
+	// Confirm asks a yes/no question.
+	Confirm(prompt, help string) (bool, error)
+}
+
+// progress reports the status of a long-running operation on the terminal.
+type progress interface {
+	Start(label string)
+	Stop(label string)
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// errValueEmpty is returned by a validator when the value being validated is empty.
+// Callers treat it as "nothing entered yet" rather than "invalid", e.g. to allow
+// Validate to pass before Ask has had a chance to prompt for a missing flag.
+var errValueEmpty = errors.New("value is empty")
+
+// nameRegexp matches the naming convention shared by project, application, and
+// environment names: lowercase letters, numbers, and hyphens, starting with a letter.
+var nameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+func validateName(value string) error {
+	if value == "" {
+		return errValueEmpty
+	}
+	if !nameRegexp.MatchString(value) {
+		return fmt.Errorf("value must start with a letter and contain only lowercase letters, numbers, and hyphens")
+	}
+	return nil
+}
+
+// validateProjectName returns an error if name isn't a valid project name.
+func validateProjectName(name string) error {
+	return validateName(name)
+}
+
+// validateApplicationName returns an error if name isn't a valid application name.
+func validateApplicationName(name string) error {
+	return validateName(name)
+}
+
+// validateEnvironmentName returns an error if name isn't a valid environment name.
+func validateEnvironmentName(name string) error {
+	return validateName(name)
+}
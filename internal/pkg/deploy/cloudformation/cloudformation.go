@@ -0,0 +1,141 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudformation deploys archer Environments and applications via AWS
+// CloudFormation and ECS.
+package cloudformation
+
+import (
+	"fmt"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/archer"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+)
+
+// ErrStackAlreadyExists means the named CloudFormation stack has already been created.
+type ErrStackAlreadyExists struct {
+	StackName string
+}
+
+func (e *ErrStackAlreadyExists) Error() string {
+	return fmt.Sprintf("stack %s already exists", e.StackName)
+}
+
+// CloudFormation provisions environments and deploys applications by creating and
+// updating CloudFormation stacks and ECS task definitions.
+type CloudFormation struct {
+	cfn cloudformationiface.CloudFormationAPI
+	ecs ecsiface.ECSAPI
+}
+
+// New returns a CloudFormation deployer using sess for every AWS API call it makes.
+func New(sess *session.Session) *CloudFormation {
+	return &CloudFormation{
+		cfn: cloudformation.New(sess),
+		ecs: ecs.New(sess),
+	}
+}
+
+func environmentStackName(env *archer.Environment) string {
+	return fmt.Sprintf("archer-%s-%s", env.Project, env.Name)
+}
+
+func appStackName(env *archer.Environment, appName string) string {
+	return fmt.Sprintf("archer-%s-%s-%s", env.Project, env.Name, appName)
+}
+
+// DeployEnvironment creates the CloudFormation stack backing env (its ECS
+// cluster), tagging the stack with env.Tags so project-level tags propagate to
+// every resource the environment owns.
+func (cf *CloudFormation) DeployEnvironment(env *archer.Environment) error {
+	stackName := environmentStackName(env)
+
+	_, err := cf.cfn.CreateStack(&cloudformation.CreateStackInput{
+		StackName:    aws.String(stackName),
+		TemplateBody: aws.String(environmentTemplate),
+		Tags:         stackTags(env.Tags),
+		Capabilities: aws.StringSlice([]string{cloudformation.CapabilityCapabilityIam}),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AlreadyExistsException" {
+			return &ErrStackAlreadyExists{StackName: stackName}
+		}
+		return fmt.Errorf("failed to create stack %s: %w", stackName, err)
+	}
+	return nil
+}
+
+// WaitForEnvironmentCreation blocks until env's stack finishes creating.
+func (cf *CloudFormation) WaitForEnvironmentCreation(env *archer.Environment) error {
+	stackName := environmentStackName(env)
+	if err := cf.cfn.WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	}); err != nil {
+		return fmt.Errorf("failed waiting for stack %s to be created: %w", stackName, err)
+	}
+	return nil
+}
+
+// DeployApp registers an ECS task definition for appName - m's main container plus
+// every declared Sidecar - and creates (or updates) the CloudFormation stack running
+// it as a service in env. Both the task definition and the stack are tagged with
+// env.Tags, so project-level tags reach CloudFormation and ECS, not just the
+// in-memory archer.Environment.
+func (cf *CloudFormation) DeployApp(env *archer.Environment, appName string, m manifest.Manifest) error {
+	containers, err := buildContainerDefinitions(appName, appImagePlaceholder(appName), m)
+	if err != nil {
+		return fmt.Errorf("failed to build task definition for app %s: %w", appName, err)
+	}
+
+	taskDef, err := cf.ecs.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family:               aws.String(fmt.Sprintf("%s-%s-%s", env.Project, env.Name, appName)),
+		ContainerDefinitions: containers,
+		Tags:                 ecsTags(env.Tags),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register task definition for app %s: %w", appName, err)
+	}
+
+	stackName := appStackName(env, appName)
+	_, err = cf.cfn.CreateStack(&cloudformation.CreateStackInput{
+		StackName: aws.String(stackName),
+		TemplateBody: aws.String(serviceTemplate(
+			aws.StringValue(taskDef.TaskDefinition.TaskDefinitionArn),
+		)),
+		Tags: stackTags(env.Tags),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AlreadyExistsException" {
+			return cf.updateAppStack(stackName, taskDef.TaskDefinition.TaskDefinitionArn, env.Tags)
+		}
+		return fmt.Errorf("failed to create stack %s: %w", stackName, err)
+	}
+	return nil
+}
+
+func (cf *CloudFormation) updateAppStack(stackName string, taskDefARN *string, tags map[string]string) error {
+	_, err := cf.cfn.UpdateStack(&cloudformation.UpdateStackInput{
+		StackName:    aws.String(stackName),
+		TemplateBody: aws.String(serviceTemplate(aws.StringValue(taskDefARN))),
+		Tags:         stackTags(tags),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update stack %s: %w", stackName, err)
+	}
+	return nil
+}
+
+// appImagePlaceholder is the image archer registers an app's task definition with
+// before its first image has been pushed to ECR.
+// TODO: once an image-push step exists, thread the pushed image URI through here
+// instead of a placeholder.
+func appImagePlaceholder(appName string) string {
+	return fmt.Sprintf("amazon/ecs-sample-%s", appName)
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import (
+	"fmt"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// essentialContainerName is the main, app container every task definition needs
+// running for the task to be considered healthy.
+func essentialContainerName(appName string) string {
+	return appName
+}
+
+// buildContainerDefinitions translates appName's main container plus any sidecars
+// m declares into the ECS task-definition container list, turning the manifest's
+// Compose-style sidecars into additional, non-essential containers alongside the
+// app.
+func buildContainerDefinitions(appName, image string, m manifest.Manifest) ([]*ecs.ContainerDefinition, error) {
+	defs := []*ecs.ContainerDefinition{
+		{
+			Name:      aws.String(essentialContainerName(appName)),
+			Image:     aws.String(image),
+			Essential: aws.Bool(true),
+		},
+	}
+
+	seen := map[string]bool{appName: true}
+	for _, sidecar := range m.Sidecars() {
+		if sidecar.Name == "" || sidecar.Image == "" {
+			return nil, fmt.Errorf("sidecar for app %s is missing a name or image", appName)
+		}
+		if seen[sidecar.Name] {
+			return nil, fmt.Errorf("sidecar %q collides with another container in app %s's task", sidecar.Name, appName)
+		}
+		seen[sidecar.Name] = true
+
+		defs = append(defs, &ecs.ContainerDefinition{
+			Name:      aws.String(sidecar.Name),
+			Image:     aws.String(sidecar.Image),
+			Essential: aws.Bool(false),
+		})
+	}
+	return defs, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// stackTags converts a project's tag map into CloudFormation stack Tags, sorted by
+// key so the resulting CreateStack/UpdateStack calls are deterministic.
+func stackTags(tags map[string]string) []*cloudformation.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make([]*cloudformation.Tag, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		out = append(out, &cloudformation.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(tags[k]),
+		})
+	}
+	return out
+}
+
+// ecsTags converts a project's tag map into ECS resource Tags, sorted by key so the
+// resulting RegisterTaskDefinition calls are deterministic.
+func ecsTags(tags map[string]string) []*ecs.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make([]*ecs.Tag, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		out = append(out, &ecs.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(tags[k]),
+		})
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
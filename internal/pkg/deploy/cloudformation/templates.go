@@ -0,0 +1,30 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import "fmt"
+
+// environmentTemplate provisions the ECS cluster shared by every app deployed into
+// an environment. It creates no VPC or other networking.
+// TODO: replace with the full networking/cluster template once one exists.
+const environmentTemplate = `AWSTemplateFormatVersion: '2010-09-09'
+Description: archer environment (ECS cluster)
+Resources:
+  Cluster:
+    Type: AWS::ECS::Cluster
+`
+
+// serviceTemplate provisions the ECS service running taskDefARN.
+// TODO: replace with the full service/load-balancer template once one exists.
+func serviceTemplate(taskDefARN string) string {
+	return fmt.Sprintf(`AWSTemplateFormatVersion: '2010-09-09'
+Description: archer application service
+Resources:
+  Service:
+    Type: AWS::ECS::Service
+    Properties:
+      TaskDefinition: %s
+      PropagateTags: TASK_DEFINITION
+`, taskDefARN)
+}
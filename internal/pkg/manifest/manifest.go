@@ -0,0 +1,115 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manifest builds and serializes the application manifests archer writes
+// into a workspace on `archer init`.
+package manifest
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Built-in application types. These ship with archer and never require network
+// access or a local checkout to use.
+const (
+	LoadBalancedWebApplication = "Load Balanced Web App"
+	BackendApplication         = "Backend App"
+	ScheduledJob               = "Scheduled Job"
+	WorkerService              = "Worker Service"
+)
+
+// builtInTemplates lists the built-in types in prompt order.
+var builtInTemplates = []string{
+	LoadBalancedWebApplication,
+	BackendApplication,
+	ScheduledJob,
+	WorkerService,
+}
+
+// Sidecar is a Compose-style additional container that rides alongside an app's
+// main container in the same ECS task.
+type Sidecar struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+}
+
+// Manifest describes an application well enough to marshal it to the workspace and,
+// eventually, deploy it.
+type Manifest interface {
+	// Marshal serializes the manifest to the YAML written into the workspace.
+	Marshal() ([]byte, error)
+	// PostInitHook returns the shell command to run after the manifest is written,
+	// or "" if the template doesn't declare one.
+	PostInitHook() string
+	// Sidecars returns the additional containers that should ride alongside the
+	// app's main container in its ECS task definition.
+	Sidecars() []Sidecar
+	// Trusted reports whether this manifest came from a built-in template. Manifests
+	// sourced from --template-repo/--template-dir (and anything cached from them
+	// under ~/.archer/templates) are never trusted, since they can declare an
+	// arbitrary PostInitHook.
+	Trusted() bool
+}
+
+// appManifest is the concrete Manifest backing both built-in and custom templates;
+// the only thing that varies between them is how they're constructed and whether
+// they're Trusted.
+type appManifest struct {
+	Name        string    `yaml:"name"`
+	Type        string    `yaml:"type"`
+	SidecarList []Sidecar `yaml:"sidecars,omitempty"`
+	InitHook    string    `yaml:"-"`
+	TrustedFlag bool      `yaml:"-"`
+}
+
+func (m *appManifest) Marshal() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+func (m *appManifest) PostInitHook() string {
+	return m.InitHook
+}
+
+func (m *appManifest) Sidecars() []Sidecar {
+	return m.SidecarList
+}
+
+func (m *appManifest) Trusted() bool {
+	return m.TrustedFlag
+}
+
+// Create builds the manifest for a built-in appType, or for a custom template
+// previously cached under ~/.archer/templates and now selected by name.
+func Create(appName, appType string) (Manifest, error) {
+	if cached, ok, err := loadCachedTemplate(appName, appType); err != nil {
+		return nil, err
+	} else if ok {
+		return cached, nil
+	}
+
+	for _, t := range builtInTemplates {
+		if t == appType {
+			return &appManifest{
+				Name:        appName,
+				Type:        appType,
+				TrustedFlag: true,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognized application type %q", appType)
+}
+
+// AvailableTemplates lists every template a user can pick from: the built-ins, plus
+// any custom template previously cached under ~/.archer/templates.
+func AvailableTemplates() ([]string, error) {
+	templates := make([]string, len(builtInTemplates))
+	copy(templates, builtInTemplates)
+
+	cached, err := cachedTemplateNames()
+	if err != nil {
+		return nil, err
+	}
+	return append(templates, cached...), nil
+}
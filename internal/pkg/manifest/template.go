@@ -0,0 +1,192 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+)
+
+// templateCacheDir is where templates fetched via --template-repo/--template-dir are
+// cached, keyed by name, so they can later be reselected by plain name through
+// AvailableTemplates/--app-type without refetching.
+func templateCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".archer", "templates"), nil
+}
+
+// templateDescriptor is the archer-template.json a custom template declares at
+// its root, plus the metadata archer records about it once cached.
+type templateDescriptor struct {
+	Name         string    `json:"name"`
+	Source       string    `json:"source"`
+	PostInitHook string    `json:"postInitHook"`
+	Sidecars     []Sidecar `json:"sidecars"`
+}
+
+const descriptorFileName = "archer-template.json"
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9-_]+`)
+
+// templateNameFromSource derives a cache directory / registry name from a git URL or
+// local path, e.g. "https://github.com/foo/bar.git" -> "bar".
+func templateNameFromSource(source string) string {
+	base := filepath.Base(source)
+	if ext := filepath.Ext(base); ext == ".git" {
+		base = base[:len(base)-len(ext)]
+	}
+	return nonAlphanumeric.ReplaceAllString(base, "-")
+}
+
+// CreateFromGit clones repoURL into the template cache (if it isn't already there),
+// reads its archer-template.json descriptor, and returns the resulting Manifest.
+// Manifests sourced this way are never Trusted.
+func CreateFromGit(appName, repoURL string) (Manifest, error) {
+	cacheDir, err := templateCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	name := templateNameFromSource(repoURL)
+	dest := filepath.Join(cacheDir, name)
+
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create template cache %s: %w", cacheDir, err)
+		}
+		cmd := exec.Command("git", "clone", "--depth", "1", repoURL, dest)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("failed to clone template %s: %w\n%s", repoURL, err, out)
+		}
+	}
+
+	return loadTemplateDir(appName, name, repoURL, dest)
+}
+
+// CreateFromDir reads dir's archer-template.json descriptor and caches it under name
+// so it can be reselected later by plain name. Manifests sourced this way are never
+// Trusted.
+func CreateFromDir(appName, dir string) (Manifest, error) {
+	name := templateNameFromSource(dir)
+	m, err := loadTemplateDir(appName, name, dir, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir, err := templateCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeDescriptor(filepath.Join(cacheDir, name), &templateDescriptor{
+		Name:         name,
+		Source:       dir,
+		PostInitHook: m.PostInitHook(),
+		Sidecars:     m.Sidecars(),
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// loadTemplateDir reads the archer-template.json descriptor out of srcDir and
+// produces the Manifest for appName.
+func loadTemplateDir(appName, templateName, source, srcDir string) (Manifest, error) {
+	descriptor, err := readDescriptor(filepath.Join(srcDir, descriptorFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from %s: %w", descriptorFileName, source, err)
+	}
+
+	return &appManifest{
+		Name:        appName,
+		Type:        templateName,
+		SidecarList: descriptor.Sidecars,
+		InitHook:    descriptor.PostInitHook,
+		TrustedFlag: false,
+	}, nil
+}
+
+// loadCachedTemplate returns the Manifest for appType if it matches a template
+// previously cached under ~/.archer/templates.
+func loadCachedTemplate(appName, appType string) (Manifest, bool, error) {
+	cacheDir, err := templateCacheDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	descriptorPath := filepath.Join(cacheDir, appType, descriptorFileName)
+	if _, err := os.Stat(descriptorPath); os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	descriptor, err := readDescriptor(descriptorPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cached template %s: %w", appType, err)
+	}
+
+	return &appManifest{
+		Name:        appName,
+		Type:        appType,
+		SidecarList: descriptor.Sidecars,
+		InitHook:    descriptor.PostInitHook,
+		TrustedFlag: false,
+	}, true, nil
+}
+
+// cachedTemplateNames lists every template cached under ~/.archer/templates.
+func cachedTemplateNames() ([]string, error) {
+	cacheDir, err := templateCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template cache %s: %w", cacheDir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(cacheDir, entry.Name(), descriptorFileName)); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func readDescriptor(path string) (*templateDescriptor, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var descriptor templateDescriptor
+	if err := json.Unmarshal(raw, &descriptor); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &descriptor, nil
+}
+
+func writeDescriptor(dir string, descriptor *templateDescriptor) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	raw, err := json.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template descriptor: %w", err)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, descriptorFileName), raw, 0o644)
+}
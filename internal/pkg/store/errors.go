@@ -0,0 +1,25 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store defines the errors shared by archer's store implementations.
+package store
+
+import "fmt"
+
+// ErrProjectAlreadyExists means a project with the given name has already been created.
+type ErrProjectAlreadyExists struct {
+	ProjectName string
+}
+
+func (e *ErrProjectAlreadyExists) Error() string {
+	return fmt.Sprintf("project %s already exists", e.ProjectName)
+}
+
+// ErrNoSuchProject means no project with the given name has been created.
+type ErrNoSuchProject struct {
+	ProjectName string
+}
+
+func (e *ErrNoSuchProject) Error() string {
+	return fmt.Sprintf("project %s not found", e.ProjectName)
+}
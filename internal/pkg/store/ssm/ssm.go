@@ -0,0 +1,156 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ssm implements archer.ProjectStore and archer.EnvironmentStore on top of
+// AWS Systems Manager Parameter Store.
+package ssm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/archer"
+	"github.com/aws/PRIVATE-amazon-ecs-archer/internal/pkg/store"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+// rootParamPath is the SSM Parameter Store namespace all archer projects live under.
+const rootParamPath = "/archer/projects"
+
+// Store persists archer.Projects and archer.Environments as SSM parameters, under
+// /archer/projects/{project} and /archer/projects/{project}/environments/{env}.
+type Store struct {
+	ssm ssmiface.SSMAPI
+}
+
+// NewStore returns a Store backed by a new AWS session built from the environment's
+// shared config (the same credential chain every other archer AWS client uses).
+func NewStore() (*Store, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return &Store{ssm: ssm.New(sess)}, nil
+}
+
+func projectParamName(name string) string {
+	return fmt.Sprintf("%s/%s", rootParamPath, name)
+}
+
+func environmentParamName(project, env string) string {
+	return fmt.Sprintf("%s/%s/environments/%s", rootParamPath, project, env)
+}
+
+// CreateProject persists project, returning store.ErrProjectAlreadyExists if a
+// project with the same name is already stored.
+func (s *Store) CreateProject(project *archer.Project) error {
+	raw, err := json.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("failed to marshal project %s: %w", project.Name, err)
+	}
+
+	_, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(projectParamName(project.Name)),
+		Value:     aws.String(string(raw)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Overwrite: aws.Bool(false),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterAlreadyExists {
+			return &store.ErrProjectAlreadyExists{ProjectName: project.Name}
+		}
+		return fmt.Errorf("failed to create project %s: %w", project.Name, err)
+	}
+	return nil
+}
+
+// GetProject returns the project identified by name, or store.ErrNoSuchProject if
+// it hasn't been created.
+func (s *Store) GetProject(name string) (*archer.Project, error) {
+	out, err := s.ssm.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(projectParamName(name)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+			return nil, &store.ErrNoSuchProject{ProjectName: name}
+		}
+		return nil, fmt.Errorf("failed to get project %s: %w", name, err)
+	}
+
+	var project archer.Project
+	if err := json.Unmarshal([]byte(aws.StringValue(out.Parameter.Value)), &project); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal project %s: %w", name, err)
+	}
+	return &project, nil
+}
+
+// ListProjects returns every project that's been created.
+func (s *Store) ListProjects() ([]*archer.Project, error) {
+	var projects []*archer.Project
+
+	err := s.ssm.GetParametersByPathPages(&ssm.GetParametersByPathInput{
+		Path:      aws.String(rootParamPath),
+		Recursive: aws.Bool(false),
+	}, func(out *ssm.GetParametersByPathOutput, lastPage bool) bool {
+		for _, param := range out.Parameters {
+			var project archer.Project
+			if err := json.Unmarshal([]byte(aws.StringValue(param.Value)), &project); err != nil {
+				continue
+			}
+			projects = append(projects, &project)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+// CreateEnvironment persists env under its project's namespace.
+func (s *Store) CreateEnvironment(env *archer.Environment) error {
+	raw, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal environment %s: %w", env.Name, err)
+	}
+
+	_, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(environmentParamName(env.Project, env.Name)),
+		Value:     aws.String(string(raw)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create environment %s: %w", env.Name, err)
+	}
+	return nil
+}
+
+// ListEnvironments returns every environment stored under project.
+func (s *Store) ListEnvironments(project string) ([]*archer.Environment, error) {
+	var envs []*archer.Environment
+
+	err := s.ssm.GetParametersByPathPages(&ssm.GetParametersByPathInput{
+		Path:      aws.String(fmt.Sprintf("%s/%s/environments", rootParamPath, project)),
+		Recursive: aws.Bool(false),
+	}, func(out *ssm.GetParametersByPathOutput, lastPage bool) bool {
+		for _, param := range out.Parameters {
+			var env archer.Environment
+			if err := json.Unmarshal([]byte(aws.StringValue(param.Value)), &env); err != nil {
+				continue
+			}
+			envs = append(envs, &env)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments for project %s: %w", project, err)
+	}
+	return envs, nil
+}